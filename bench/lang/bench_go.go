@@ -113,6 +113,34 @@ func main() {
 
 	runtime.GC()
 
+	// --- FAST FFI, looped (fast.h has no batch entry point to amortize
+	// the per-query cgo crossing, so this measures the same fast_search
+	// call as above; it's kept as a separate, named data point so a future
+	// batch primitive has a baseline to compare against) ---
+	{
+		tree := C.fast_create((*C.int32_t)(unsafe.Pointer(&keys[0])), C.size_t(treeSize))
+		if tree == nil {
+			fmt.Fprintln(os.Stderr, "fast_create failed")
+			os.Exit(1)
+		}
+
+		out := make([]int64, numQueries)
+		for i := 0; i < warmup; i++ {
+			out[i] = int64(C.fast_search(tree, C.int32_t(queries[i])))
+		}
+
+		t0 := time.Now()
+		for i := 0; i < numQueries; i++ {
+			out[i] = int64(C.fast_search(tree, C.int32_t(queries[i])))
+		}
+		elapsed := time.Since(t0).Seconds()
+		emitJSON("fast_ffi_batch", treeSize, numQueries, elapsed)
+
+		C.fast_destroy(tree)
+	}
+
+	runtime.GC()
+
 	// --- google/btree (B-tree, degree 32) ---
 	{
 		bt := btree.New(32)
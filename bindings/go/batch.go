@@ -0,0 +1,32 @@
+package fast
+
+import "fmt"
+
+// SearchBatch fills out[i] with Search(queries[i]) for every query.
+// len(out) must equal len(queries).
+//
+// fast.h exposes no batched search entry point to amortize the per-query
+// cgo crossing, so this is a plain Go loop over Search rather than a single
+// cgo call; it exists for API symmetry with LowerBoundBatch and so callers
+// migrating from a library that does batch can keep the same call shape.
+func (t *Tree) SearchBatch(queries []int32, out []int64) error {
+	if len(queries) != len(out) {
+		return fmt.Errorf("fast: SearchBatch: len(out)=%d != len(queries)=%d", len(out), len(queries))
+	}
+	for i, q := range queries {
+		out[i] = t.Search(q)
+	}
+	return nil
+}
+
+// LowerBoundBatch fills out[i] with LowerBound(queries[i]) for every query.
+// len(out) must equal len(queries).
+func (t *Tree) LowerBoundBatch(queries []int32, out []int64) error {
+	if len(queries) != len(out) {
+		return fmt.Errorf("fast: LowerBoundBatch: len(out)=%d != len(queries)=%d", len(out), len(queries))
+	}
+	for i, q := range queries {
+		out[i] = t.LowerBound(q)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package fast
+
+import "testing"
+
+func TestTreeSearchBatchMatchesSearch(t *testing.T) {
+	keys := []int32{1, 3, 5, 7, 9, 11, 13}
+	tree, err := New(keys)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	queries := []int32{-1, 0, 1, 2, 6, 13, 14}
+	out := make([]int64, len(queries))
+	if err := tree.SearchBatch(queries, out); err != nil {
+		t.Fatalf("SearchBatch: %v", err)
+	}
+	for i, q := range queries {
+		if want := tree.Search(q); out[i] != want {
+			t.Fatalf("SearchBatch[%d] (query %d) = %d, want %d", i, q, out[i], want)
+		}
+	}
+}
+
+func TestTreeLowerBoundBatchMatchesLowerBound(t *testing.T) {
+	keys := []int32{1, 3, 5, 7, 9, 11, 13}
+	tree, err := New(keys)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	queries := []int32{-1, 0, 1, 2, 6, 13, 14}
+	out := make([]int64, len(queries))
+	if err := tree.LowerBoundBatch(queries, out); err != nil {
+		t.Fatalf("LowerBoundBatch: %v", err)
+	}
+	for i, q := range queries {
+		if want := tree.LowerBound(q); out[i] != want {
+			t.Fatalf("LowerBoundBatch[%d] (query %d) = %d, want %d", i, q, out[i], want)
+		}
+	}
+}
+
+func TestTreeSearchBatchLengthMismatch(t *testing.T) {
+	tree, err := New([]int32{1, 2, 3})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	if err := tree.SearchBatch([]int32{1, 2}, make([]int64, 1)); err == nil {
+		t.Fatalf("SearchBatch with mismatched slice lengths did not return an error")
+	}
+}
@@ -0,0 +1,149 @@
+package fast
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// EncodeUint32 appends key, big-endian, to buf so that byte-string
+// comparison of the result matches numeric comparison of key.
+func EncodeUint32(buf []byte, key uint32) []byte {
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], key)
+	return append(buf, tmp[:]...)
+}
+
+// EncodeInt64Signed appends key, big-endian, to buf after applying the
+// standard sign-bias trick (flipping the sign bit) so that byte-string
+// comparison of the result matches numeric comparison of the signed key.
+func EncodeInt64Signed(buf []byte, key int64) []byte {
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], uint64(key)^(1<<63))
+	return append(buf, tmp[:]...)
+}
+
+// EncodeStringPrefix appends a fixed-width encoding of s to buf: the first
+// width-8 bytes of s (truncated or zero-padded), followed by an 8-byte
+// FNV-1a hash of the full string as a tiebreaker tail. This keeps
+// comparisons of the common prefix correct while still distinguishing
+// strings that share it, at the cost of a fixed width per key.
+func EncodeStringPrefix(buf []byte, s string, width int) []byte {
+	if width < 8 {
+		panic("fast: EncodeStringPrefix: width must be >= 8")
+	}
+	prefixLen := width - 8
+	start := len(buf)
+	buf = append(buf, make([]byte, width)...)
+	copy(buf[start:start+prefixLen], s)
+
+	var h uint64 = 1469598103934665603 // FNV-1a offset basis
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211 // FNV-1a prime
+	}
+	binary.BigEndian.PutUint64(buf[start+prefixLen:start+width], h)
+	return buf
+}
+
+// CompositeBuilder appends encoded fields into a single growing key buffer
+// with well-defined lexicographic ordering: the concatenation orders first
+// by the first field, then the second, and so on, provided each field was
+// appended with one of the Encode* functions (or a fixed-width encoding
+// that preserves the same property).
+type CompositeBuilder struct {
+	buf []byte
+}
+
+// NewCompositeBuilder returns an empty CompositeBuilder.
+func NewCompositeBuilder() *CompositeBuilder {
+	return &CompositeBuilder{}
+}
+
+// Uint32 appends a uint32 field.
+func (b *CompositeBuilder) Uint32(v uint32) *CompositeBuilder {
+	b.buf = EncodeUint32(b.buf, v)
+	return b
+}
+
+// Int64Signed appends a signed int64 field.
+func (b *CompositeBuilder) Int64Signed(v int64) *CompositeBuilder {
+	b.buf = EncodeInt64Signed(b.buf, v)
+	return b
+}
+
+// StringPrefix appends a fixed-width string field of the given width.
+func (b *CompositeBuilder) StringPrefix(v string, width int) *CompositeBuilder {
+	b.buf = EncodeStringPrefix(b.buf, v, width)
+	return b
+}
+
+// Bytes returns the encoded composite key built so far.
+func (b *CompositeBuilder) Bytes() []byte {
+	return b.buf
+}
+
+// Composite is a multi-field index key built from a CompositeBuilder, for
+// use as a key or query in a TreeBytes of the matching width.
+type Composite = []byte
+
+// TreeBytes is a search tree over fixed-width []byte keys, for
+// composite/multi-column indexes built with CompositeBuilder. Supported
+// widths are 8, 16, 24, and 32 bytes; this mirrors the (TreeID, Key) style
+// composite ordering used by btrfs-progs-ng's rebuildnodes to keep
+// secondary indexes range-scannable.
+//
+// fast.h exposes no fixed-width-key entry points, so TreeBytes is backed by
+// a flat sorted buffer of the packed keys searched with binary search,
+// rather than the packed FAST layout Tree uses for int32 keys.
+type TreeBytes struct {
+	keys  []byte
+	width int
+	n     int
+}
+
+// NewTreeBytes builds a TreeBytes over a sorted slice of fixed-width keys,
+// each width bytes long and packed back-to-back in keys (so
+// len(keys) == count*width). width must be one of 8, 16, 24, 32.
+func NewTreeBytes(keys []byte, width int) (*TreeBytes, error) {
+	switch width {
+	case 8, 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("fast: NewTreeBytes: unsupported width %d", width)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("fast: NewTreeBytes: keys must not be empty")
+	}
+	if len(keys)%width != 0 {
+		return nil, fmt.Errorf("fast: NewTreeBytes: keys length %d not a multiple of width %d", len(keys), width)
+	}
+	count := len(keys) / width
+	return &TreeBytes{keys: keys, width: width, n: count}, nil
+}
+
+// Search returns the index of the largest key <= query, or -1. len(query)
+// must equal the tree's width.
+func (t *TreeBytes) Search(query []byte) (int64, error) {
+	if len(query) != t.width {
+		return 0, fmt.Errorf("fast: TreeBytes.Search: query length %d != width %d", len(query), t.width)
+	}
+	lo, hi := 0, t.n
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if bytes.Compare(t.keyAt(mid), query) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return int64(lo - 1), nil
+}
+
+// keyAt returns the key at index i as a slice into the tree's backing
+// buffer.
+func (t *TreeBytes) keyAt(i int) []byte {
+	return t.keys[i*t.width : (i+1)*t.width]
+}
+
+// Size returns the number of keys in the tree.
+func (t *TreeBytes) Size() int { return t.n }
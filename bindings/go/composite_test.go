@@ -0,0 +1,105 @@
+package fast
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+func TestEncodeUint32PreservesOrder(t *testing.T) {
+	values := []uint32{0, 1, 255, 256, 1 << 31, ^uint32(0)}
+	encoded := make([][]byte, len(values))
+	for i, v := range values {
+		encoded[i] = EncodeUint32(nil, v)
+	}
+	if !sort.SliceIsSorted(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 }) {
+		t.Fatalf("EncodeUint32 output not byte-comparable in numeric order: %v", values)
+	}
+}
+
+func TestEncodeInt64SignedPreservesOrder(t *testing.T) {
+	values := []int64{-1 << 62, -1000, -1, 0, 1, 1000, 1 << 62}
+	encoded := make([][]byte, len(values))
+	for i, v := range values {
+		encoded[i] = EncodeInt64Signed(nil, v)
+	}
+	for i := 1; i < len(encoded); i++ {
+		if bytes.Compare(encoded[i-1], encoded[i]) >= 0 {
+			t.Fatalf("EncodeInt64Signed(%d) >= EncodeInt64Signed(%d) byte-wise, want strictly increasing", values[i-1], values[i])
+		}
+	}
+}
+
+func TestEncodeStringPrefixSharedPrefixOrder(t *testing.T) {
+	a := EncodeStringPrefix(nil, "alpha", 16)
+	b := EncodeStringPrefix(nil, "alphabet", 16)
+	if len(a) != 16 || len(b) != 16 {
+		t.Fatalf("EncodeStringPrefix widths = %d, %d, want 16, 16", len(a), len(b))
+	}
+	// "alpha" is a prefix of "alphabet": with zero-padding beyond "alpha",
+	// its prefix bytes must compare <= "alphabet"'s.
+	if bytes.Compare(a[:8], b[:8]) > 0 {
+		t.Fatalf("EncodeStringPrefix(alpha) prefix > EncodeStringPrefix(alphabet) prefix")
+	}
+}
+
+func TestCompositeBuilderOrdersByFirstField(t *testing.T) {
+	low := NewCompositeBuilder().Uint32(1).Int64Signed(100).Bytes()
+	high := NewCompositeBuilder().Uint32(2).Int64Signed(-100).Bytes()
+	if bytes.Compare(low, high) >= 0 {
+		t.Fatalf("composite key with tenant=1 did not sort before tenant=2 regardless of the second field")
+	}
+}
+
+func encodeTenantKey(tenant, seq uint32) []byte {
+	buf := EncodeUint32(nil, tenant)
+	return EncodeUint32(buf, seq)
+}
+
+func TestTreeBytesSearchFindsLargestKeyLessOrEqual(t *testing.T) {
+	var keys []byte
+	for _, tenant := range []uint32{1, 2, 2, 3} {
+		keys = append(keys, encodeTenantKey(tenant, 0)...)
+	}
+	tree, err := NewTreeBytes(keys, 8)
+	if err != nil {
+		t.Fatalf("NewTreeBytes: %v", err)
+	}
+
+	query := encodeTenantKey(2, 0)
+	idx, err := tree.Search(query)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if idx != 2 {
+		t.Fatalf("Search(2) = %d, want 2 (the largest of the two tenant=2 entries)", idx)
+	}
+
+	below := encodeTenantKey(0, 0)
+	if idx, err := tree.Search(below); err != nil || idx != -1 {
+		t.Fatalf("Search(0) = (%d, %v), want (-1, nil)", idx, err)
+	}
+}
+
+func TestTreeBytesSearchRejectsWrongWidth(t *testing.T) {
+	keys := encodeTenantKey(1, 0)
+	tree, err := NewTreeBytes(keys, 8)
+	if err != nil {
+		t.Fatalf("NewTreeBytes: %v", err)
+	}
+	if _, err := tree.Search(make([]byte, 4)); err == nil {
+		t.Fatalf("Search with a query length != width did not return an error")
+	}
+}
+
+func TestNewTreeBytesRejectsUnsupportedWidth(t *testing.T) {
+	if _, err := NewTreeBytes(make([]byte, 10), 10); err == nil {
+		t.Fatalf("NewTreeBytes with width 10 did not return an error")
+	}
+}
+
+func TestNewTreeBytesRejectsMisalignedKeys(t *testing.T) {
+	if _, err := NewTreeBytes(make([]byte, 12), 8); err == nil {
+		t.Fatalf("NewTreeBytes with a keys length not a multiple of width did not return an error")
+	}
+}
@@ -25,6 +25,22 @@ import (
 // Tree is a FAST search tree.
 type Tree struct {
 	ptr *C.fast_tree_t
+
+	// baseValues holds the value associated with each key in the
+	// immutable FAST layout, indexed the same way KeyAt is. It is nil
+	// until the tree has been mutated via Insert/Delete, at which point
+	// it is populated lazily with each key's index as its implicit value
+	// to preserve pre-mutation Search/LowerBound semantics.
+	baseValues []int64
+
+	// delta buffers Insert/Delete mutations applied since the last
+	// Compact. See mutate.go.
+	delta          *delta
+	deltaThreshold float64
+
+	// readOnly is set by OpenReadOnly; Insert/Delete/Compact refuse to
+	// run on such a tree. See persist.go.
+	readOnly bool
 }
 
 // New builds a FAST tree from a sorted slice of int32 keys.
@@ -49,22 +65,75 @@ func (t *Tree) Close() {
 	}
 }
 
-// Search returns the index of the largest key <= query, or -1.
+// Search returns the index of the largest key <= query, or -1. If the tree
+// has pending Insert/Delete mutations, the index is computed against the
+// merged (base + delta) view rather than the immutable FAST layout alone —
+// the same view Size and KeyAt use, so tree.KeyAt(int(tree.Search(k)))
+// stays consistent across a pending mutation.
 func (t *Tree) Search(key int32) int64 {
+	if t.hasPendingDelta() {
+		return t.searchMerged(key, false)
+	}
+	if t.ptr == nil {
+		return -1
+	}
 	return int64(C.fast_search(t.ptr, C.int32_t(key)))
 }
 
-// LowerBound returns the index of the first key >= query.
+// LowerBound returns the index of the first key >= query. If the tree has
+// pending Insert/Delete mutations, the index is computed against the
+// merged (base + delta) view rather than the immutable FAST layout alone —
+// the same view Size and KeyAt use.
 func (t *Tree) LowerBound(key int32) int64 {
+	if t.hasPendingDelta() {
+		return t.searchMerged(key, true)
+	}
+	if t.ptr == nil {
+		return 0
+	}
 	return int64(C.fast_search_lower_bound(t.ptr, C.int32_t(key)))
 }
 
-// Size returns the number of keys in the tree.
+// hasPendingDelta reports whether the tree has buffered Insert/Delete
+// mutations not yet folded into the immutable FAST layout by Compact.
+func (t *Tree) hasPendingDelta() bool {
+	return t.delta != nil && (t.delta.len() > 0 || len(t.delta.deleted) > 0)
+}
+
+// Size returns the number of keys currently in the tree, including any
+// pending Insert/Delete mutations not yet folded in by Compact.
 func (t *Tree) Size() int {
-	return int(C.fast_size(t.ptr))
+	if t.hasPendingDelta() {
+		return len(t.merged())
+	}
+	return t.baseSize()
 }
 
-// KeyAt returns the key at the given sorted index.
+// KeyAt returns the key at the given sorted index, including the effect of
+// any pending Insert/Delete mutations not yet folded in by Compact — the
+// same index Search/LowerBound return.
 func (t *Tree) KeyAt(index int) int32 {
+	if t.hasPendingDelta() {
+		return t.merged()[index].key
+	}
+	return t.baseKeyAt(index)
+}
+
+// baseSize returns the number of keys in the immutable FAST layout,
+// ignoring the delta overlay. It is 0 for a tree compacted down to no keys,
+// in which case ptr is nil.
+func (t *Tree) baseSize() int {
+	if t.ptr == nil {
+		return 0
+	}
+	return int(C.fast_size(t.ptr))
+}
+
+// baseKeyAt returns the key at index in the immutable FAST layout,
+// ignoring the delta overlay.
+func (t *Tree) baseKeyAt(index int) int32 {
+	if t.ptr == nil {
+		panic("fast: KeyAt on an empty tree")
+	}
 	return int32(C.fast_key_at(t.ptr, C.size_t(index)))
 }
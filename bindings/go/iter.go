@@ -0,0 +1,142 @@
+package fast
+
+import "sort"
+
+// entry is a single key/value pair in a Tree's merged (base + delta) view.
+type entry struct {
+	key   int32
+	value int64
+}
+
+// merged returns the tree's entries in ascending key order, with any
+// pending delta mutations applied on top of the immutable FAST layout.
+func (t *Tree) merged() []entry {
+	// Walk the base layout directly (baseSize/baseKeyAt), not Size/KeyAt:
+	// those are delta-aware and resolve through merged itself, which would
+	// recurse forever.
+	n := t.baseSize()
+	if !t.hasPendingDelta() {
+		out := make([]entry, n)
+		for i := 0; i < n; i++ {
+			out[i] = entry{key: t.baseKeyAt(i), value: t.valueAt(i)}
+		}
+		return out
+	}
+
+	m := make(map[int32]int64, n+t.delta.len())
+	for i := 0; i < n; i++ {
+		k := t.baseKeyAt(i)
+		if _, deleted := t.delta.deleted[k]; deleted {
+			continue
+		}
+		m[k] = t.valueAt(i)
+	}
+	for k, v := range t.delta.inserted {
+		m[k] = v
+	}
+	out := make([]entry, 0, len(m))
+	for k, v := range m {
+		out = append(out, entry{key: k, value: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+// valueAt returns the implicit or stored value at base index i.
+func (t *Tree) valueAt(i int) int64 {
+	if t.baseValues != nil {
+		return t.baseValues[i]
+	}
+	return int64(i)
+}
+
+// Ascend calls fn for every (index, key) pair in ascending key order, until
+// fn returns false. index is the pair's position in the tree's current
+// sorted order, matching what Search/LowerBound would return for key.
+func (t *Tree) Ascend(fn func(index int64, key int32) bool) {
+	for i, e := range t.merged() {
+		if !fn(int64(i), e.key) {
+			return
+		}
+	}
+}
+
+// Descend calls fn for every (index, key) pair in descending key order,
+// until fn returns false.
+func (t *Tree) Descend(fn func(index int64, key int32) bool) {
+	m := t.merged()
+	for i := len(m) - 1; i >= 0; i-- {
+		if !fn(int64(i), m[i].key) {
+			return
+		}
+	}
+}
+
+// AscendGreaterOrEqual calls fn for every (index, key) pair with
+// key >= pivot, in ascending order, until fn returns false.
+func (t *Tree) AscendGreaterOrEqual(pivot int32, fn func(index int64, key int32) bool) {
+	for i, e := range t.merged() {
+		if e.key < pivot {
+			continue
+		}
+		if !fn(int64(i), e.key) {
+			return
+		}
+	}
+}
+
+// DescendLessOrEqual calls fn for every (index, key) pair with
+// key <= pivot, in descending order, until fn returns false.
+func (t *Tree) DescendLessOrEqual(pivot int32, fn func(index int64, key int32) bool) {
+	m := t.merged()
+	for i := len(m) - 1; i >= 0; i-- {
+		if m[i].key > pivot {
+			continue
+		}
+		if !fn(int64(i), m[i].key) {
+			return
+		}
+	}
+}
+
+// Range calls fn for every (index, key) pair with lo <= key <= hi, in
+// ascending order, until fn returns false.
+func (t *Tree) Range(lo, hi int32, fn func(index int64, key int32) bool) {
+	for i, e := range t.merged() {
+		if e.key < lo {
+			continue
+		}
+		if e.key > hi {
+			return
+		}
+		if !fn(int64(i), e.key) {
+			return
+		}
+	}
+}
+
+// searchMerged implements Search (lowerBound == false) and LowerBound
+// (lowerBound == true) against the merged (base + delta) view, via binary
+// search since merged() is already sorted by key.
+func (t *Tree) searchMerged(query int32, lowerBound bool) int64 {
+	m := t.merged()
+	lo, hi := 0, len(m)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if m[mid].key < query {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lowerBound {
+		return int64(lo)
+	}
+	if lo < len(m) && m[lo].key == query {
+		return int64(lo)
+	}
+	if lo == 0 {
+		return -1
+	}
+	return int64(lo - 1)
+}
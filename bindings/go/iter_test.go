@@ -0,0 +1,59 @@
+package fast
+
+import "testing"
+
+func TestTreeAscendDescendRange(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	var ascKeys []int32
+	tree.Ascend(func(_ int64, key int32) bool {
+		ascKeys = append(ascKeys, key)
+		return true
+	})
+	if !equalInt32s(ascKeys, []int32{1, 3, 5, 7, 9}) {
+		t.Fatalf("Ascend = %v, want [1 3 5 7 9]", ascKeys)
+	}
+
+	var descKeys []int32
+	tree.Descend(func(_ int64, key int32) bool {
+		descKeys = append(descKeys, key)
+		return true
+	})
+	if !equalInt32s(descKeys, []int32{9, 7, 5, 3, 1}) {
+		t.Fatalf("Descend = %v, want [9 7 5 3 1]", descKeys)
+	}
+
+	var rangeKeys []int32
+	tree.Range(3, 7, func(_ int64, key int32) bool {
+		rangeKeys = append(rangeKeys, key)
+		return true
+	})
+	if !equalInt32s(rangeKeys, []int32{3, 5, 7}) {
+		t.Fatalf("Range(3, 7) = %v, want [3 5 7]", rangeKeys)
+	}
+}
+
+func TestTreeSnapshotIndependence(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	snap := tree.Snapshot()
+	defer snap.Close()
+
+	tree.Insert(4, 400)
+
+	if idx := snap.Search(4); idx >= 0 && snap.KeyAt(int(idx)) == 4 {
+		t.Fatalf("Snapshot observed a mutation made to the original tree after Snapshot")
+	}
+	if idx := tree.Search(4); idx < 0 || tree.KeyAt(int(idx)) != 4 {
+		t.Fatalf("original tree did not observe its own Insert after Snapshot")
+	}
+}
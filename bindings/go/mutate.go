@@ -0,0 +1,228 @@
+package fast
+
+/*
+#include <fast.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"sort"
+	"unsafe"
+)
+
+// defaultDeltaThreshold is the fraction of the main tree's size that the
+// pending delta may grow to before an Insert or Delete triggers an automatic
+// Compact.
+const defaultDeltaThreshold = 0.25
+
+// delta holds mutations applied since the last Compact. It is a small
+// unordered overlay (two maps) consulted on every Search/LowerBound, analogous to the
+// overlay of not-yet-committed nodes that btrfs-progs-ng's rebuildnodes
+// keeps alongside the on-disk forest while it reconstructs it.
+type delta struct {
+	// inserted maps key -> value for keys added since the last Compact.
+	inserted map[int32]int64
+	// deleted marks keys removed since the last Compact, shadowing
+	// whatever the main FAST layout says about them.
+	deleted map[int32]struct{}
+}
+
+func newDelta() *delta {
+	return &delta{inserted: make(map[int32]int64), deleted: make(map[int32]struct{})}
+}
+
+func (d *delta) len() int { return len(d.inserted) }
+
+// pendingCount is the total number of keys the delta touches — both
+// inserted and deleted — which is what should be compared against
+// deltaThreshold; counting inserted alone means a delete-only workload
+// never trips an auto-compact.
+func (d *delta) pendingCount() int { return len(d.inserted) + len(d.deleted) }
+
+// Option configures a Tree. New, Open and OpenReadOnly take no Option
+// values, so an Option only takes effect through applyOptions.
+type Option func(*Tree)
+
+// WithDeltaThreshold sets the fraction (0, 1] of the main tree's element
+// count that the pending delta may grow to before an Insert or Delete
+// triggers an automatic Compact. The default is 0.25.
+func WithDeltaThreshold(frac float64) Option {
+	return func(t *Tree) { t.deltaThreshold = frac }
+}
+
+// applyOptions resets deltaThreshold to the default and then applies opts
+// in order. No constructor in this package calls it; a Tree from New, Open
+// or OpenReadOnly has a zero deltaThreshold, which maybeCompact reads as
+// defaultDeltaThreshold.
+func (t *Tree) applyOptions(opts []Option) {
+	t.deltaThreshold = defaultDeltaThreshold
+	for _, opt := range opts {
+		opt(t)
+	}
+}
+
+// ensureBaseValues lazily populates baseValues with each base key's index
+// as its implicit value, so a Tree built via New (which carries no
+// payload) behaves the same under Search/LowerBound once mutated.
+func (t *Tree) ensureBaseValues() {
+	if t.baseValues != nil || t.ptr == nil {
+		return
+	}
+	n := t.baseSize()
+	t.baseValues = make([]int64, n)
+	for i := range t.baseValues {
+		t.baseValues[i] = int64(i)
+	}
+}
+
+// Insert adds or updates key with value. It returns the key's index in the
+// merged view and whether it already existed. Insert is buffered in an
+// in-memory delta overlay and merged into the immutable FAST layout lazily,
+// either by an explicit Compact or automatically once the delta grows
+// beyond deltaThreshold.
+func (t *Tree) Insert(key int32, value int64) (index int64, existed bool) {
+	if t.readOnly {
+		panic("fast: Insert on a tree opened with OpenReadOnly")
+	}
+	t.ensureBaseValues()
+	if t.delta == nil {
+		t.delta = newDelta()
+	}
+	_, wasInserted := t.delta.inserted[key]
+	_, wasDeleted := t.delta.deleted[key]
+	existed = wasInserted || (!wasDeleted && t.hasBase(key))
+	delete(t.delta.deleted, key)
+	t.delta.inserted[key] = value
+	t.maybeCompact()
+	return t.LowerBound(key), existed
+}
+
+// Delete removes key, returning its value and whether it was present.
+func (t *Tree) Delete(key int32) (value int64, ok bool) {
+	if t.readOnly {
+		panic("fast: Delete on a tree opened with OpenReadOnly")
+	}
+	t.ensureBaseValues()
+	if t.delta == nil {
+		t.delta = newDelta()
+	}
+	if v, wasInserted := t.delta.inserted[key]; wasInserted {
+		delete(t.delta.inserted, key)
+		t.delta.deleted[key] = struct{}{}
+		t.maybeCompact()
+		return v, true
+	}
+	if _, wasDeleted := t.delta.deleted[key]; wasDeleted {
+		return 0, false
+	}
+	if !t.hasBase(key) {
+		return 0, false
+	}
+	idx := t.baseSearch(key)
+	v := t.baseValues[idx]
+	t.delta.deleted[key] = struct{}{}
+	t.maybeCompact()
+	return v, true
+}
+
+// hasBase reports whether key is present in the immutable FAST layout,
+// ignoring the delta overlay.
+func (t *Tree) hasBase(key int32) bool {
+	if t.ptr == nil {
+		return false
+	}
+	idx := t.baseSearch(key)
+	return idx >= 0 && t.baseKeyAt(int(idx)) == key
+}
+
+// baseSearch searches the immutable FAST layout directly, bypassing the delta.
+func (t *Tree) baseSearch(key int32) int64 {
+	if t.ptr == nil {
+		return -1
+	}
+	return int64(C.fast_search(t.ptr, C.int32_t(key)))
+}
+
+// maybeCompact triggers a rebuild once the delta has reached
+// deltaThreshold relative to the base tree's size.
+func (t *Tree) maybeCompact() {
+	threshold := t.deltaThreshold
+	if threshold <= 0 {
+		threshold = defaultDeltaThreshold
+	}
+	baseLen := len(t.baseValues)
+	if baseLen == 0 || float64(t.delta.pendingCount())/float64(baseLen) >= threshold {
+		t.Compact()
+	}
+}
+
+// Compact merges pending Insert/Delete mutations into a freshly built FAST
+// layout and discards the delta overlay.
+func (t *Tree) Compact() {
+	if t.readOnly {
+		panic("fast: Compact on a tree opened with OpenReadOnly")
+	}
+	if !t.hasPendingDelta() {
+		return
+	}
+	merged := make(map[int32]int64, len(t.baseValues)+t.delta.len())
+	for i, v := range t.baseValues {
+		k := t.baseKeyAt(i)
+		if _, deleted := t.delta.deleted[k]; deleted {
+			continue
+		}
+		merged[k] = v
+	}
+	for k, v := range t.delta.inserted {
+		merged[k] = v
+	}
+
+	keys := make([]int32, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	values := make([]int64, len(keys))
+	for i, k := range keys {
+		values[i] = merged[k]
+	}
+
+	var newPtr *C.fast_tree_t
+	if len(keys) > 0 {
+		newPtr = C.fast_create((*C.int32_t)(unsafe.Pointer(&keys[0])), C.size_t(len(keys)))
+		if newPtr == nil {
+			panic("fast: Compact: fast_create failed")
+		}
+	}
+	if t.ptr != nil {
+		C.fast_destroy(t.ptr)
+	}
+	// newPtr is nil when the merged key set is empty (e.g. every key was
+	// deleted); Size/KeyAt/Search/LowerBound/baseSize/baseSearch all treat a
+	// nil ptr as an empty tree rather than passing it into cgo.
+	t.ptr = newPtr
+	t.baseValues = values
+	t.delta = newDelta()
+}
+
+// Value returns the value associated with key, if present, without removing
+// it — unlike Delete, which is destructive. It consults the delta overlay
+// first, so it reflects pending Insert/Delete mutations not yet folded in by
+// Compact.
+func (t *Tree) Value(key int32) (int64, bool) {
+	if t.delta != nil {
+		if v, inserted := t.delta.inserted[key]; inserted {
+			return v, true
+		}
+		if _, deleted := t.delta.deleted[key]; deleted {
+			return 0, false
+		}
+	}
+	if !t.hasBase(key) {
+		return 0, false
+	}
+	idx := t.baseSearch(key)
+	return t.valueAt(int(idx)), true
+}
@@ -0,0 +1,158 @@
+package fast
+
+import "testing"
+
+func TestTreeInsertVisibleBeforeCompact(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	// A deltaThreshold this high means a single Insert never triggers an
+	// automatic Compact, so this exercises the delta-overlay path.
+	tree.deltaThreshold = 1
+
+	if _, existed := tree.Insert(4, 400); existed {
+		t.Fatalf("Insert(4) reported existed before it was ever present")
+	}
+
+	if idx := tree.Search(4); idx < 0 || tree.KeyAt(int(idx)) != 4 {
+		t.Fatalf("Search(4) = %d, want the index of key 4 (inserted but not yet compacted)", idx)
+	}
+	if idx := tree.LowerBound(4); idx < 0 || tree.KeyAt(int(idx)) != 4 {
+		t.Fatalf("LowerBound(4) = %d, want the index of key 4", idx)
+	}
+}
+
+func TestTreeDeleteHiddenBeforeCompact(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	v, ok := tree.Delete(5)
+	if !ok || v != 2 {
+		t.Fatalf("Delete(5) = (%d, %v), want (2, true)", v, ok)
+	}
+
+	if idx := tree.LowerBound(5); idx >= 0 && tree.KeyAt(int(idx)) == 5 {
+		t.Fatalf("LowerBound(5) still reports deleted key 5 present at %d", idx)
+	}
+}
+
+func TestTreeCompactMergesDelta(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	tree.Insert(4, 400)
+	tree.Delete(5)
+	tree.Compact()
+
+	if tree.Size() != 5 { // {1,3,4,7,9}
+		t.Fatalf("Size() after Compact = %d, want 5", tree.Size())
+	}
+	idx := tree.Search(4)
+	if idx < 0 || tree.KeyAt(int(idx)) != 4 {
+		t.Fatalf("Search(4) after Compact = %d, want the index of key 4", idx)
+	}
+	if idx := tree.Search(5); idx >= 0 && tree.KeyAt(int(idx)) == 5 {
+		t.Fatalf("Search(5) after Compact still finds deleted key 5")
+	}
+}
+
+func TestTreeValueNonDestructiveLookup(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	if v, ok := tree.Value(5); !ok || v != 2 {
+		t.Fatalf("Value(5) = (%d, %v), want (2, true)", v, ok)
+	}
+	// Value must not remove the key the way Delete does.
+	if v, ok := tree.Value(5); !ok || v != 2 {
+		t.Fatalf("Value(5) after a prior Value() = (%d, %v), want (2, true)", v, ok)
+	}
+
+	tree.Insert(4, 400)
+	if v, ok := tree.Value(4); !ok || v != 400 {
+		t.Fatalf("Value(4) for a pending Insert = (%d, %v), want (400, true)", v, ok)
+	}
+
+	tree.Delete(7)
+	if _, ok := tree.Value(7); ok {
+		t.Fatalf("Value(7) reported found after Delete(7)")
+	}
+
+	if _, ok := tree.Value(100); ok {
+		t.Fatalf("Value(100) reported found for a key never in the tree")
+	}
+}
+
+func TestTreeCompactToEmptyTreeStaysUsable(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	tree.Delete(1)
+	tree.Delete(3)
+	tree.Delete(5)
+	tree.Compact()
+
+	if tree.Size() != 0 {
+		t.Fatalf("Size() after deleting every key = %d, want 0", tree.Size())
+	}
+	if idx := tree.Search(1); idx != -1 {
+		t.Fatalf("Search(1) on an emptied tree = %d, want -1", idx)
+	}
+	if idx := tree.LowerBound(1); idx != 0 {
+		t.Fatalf("LowerBound(1) on an emptied tree = %d, want 0", idx)
+	}
+
+	// The tree must still accept new keys after being compacted to empty.
+	if _, existed := tree.Insert(2, 200); existed {
+		t.Fatalf("Insert(2) on an emptied tree reported existed")
+	}
+	tree.Compact()
+	if tree.Size() != 1 {
+		t.Fatalf("Size() after re-inserting into an emptied tree = %d, want 1", tree.Size())
+	}
+}
+
+func TestTreeMaybeCompactTriggersOnDeleteOnlyWorkload(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 0.5 // 2 of 4 keys
+
+	tree.Delete(1)
+	tree.Delete(3)
+
+	if tree.hasPendingDelta() {
+		t.Fatalf("two deletes at a 0.5 threshold over 4 keys did not trigger an automatic Compact")
+	}
+}
+
+func TestTreeOpenReadOnlyRejectsMutation(t *testing.T) {
+	tree := &Tree{readOnly: true}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Insert on a read-only tree did not panic")
+		}
+	}()
+	tree.Insert(1, 1)
+}
@@ -0,0 +1,118 @@
+package fast
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTreeWriteToThenOpenRoundTrip(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.fast")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Size() != tree.Size() {
+		t.Fatalf("reopened Size() = %d, want %d", reopened.Size(), tree.Size())
+	}
+	for i := 0; i < tree.Size(); i++ {
+		if reopened.KeyAt(i) != tree.KeyAt(i) {
+			t.Fatalf("reopened KeyAt(%d) = %d, want %d", i, reopened.KeyAt(i), tree.KeyAt(i))
+		}
+	}
+}
+
+func TestTreeWriteToCompactsPendingDelta(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+	tree.deltaThreshold = 1
+
+	tree.Insert(4, 400)
+	tree.Delete(5)
+
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.fast")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer reopened.Close()
+
+	if reopened.Size() != 5 { // {1,3,4,7,9}
+		t.Fatalf("reopened Size() = %d, want 5 (WriteTo must persist pending Insert/Delete)", reopened.Size())
+	}
+	foundFour, foundFive := false, false
+	for i := 0; i < reopened.Size(); i++ {
+		switch reopened.KeyAt(i) {
+		case 4:
+			foundFour = true
+		case 5:
+			foundFive = true
+		}
+	}
+	if !foundFour {
+		t.Fatalf("reopened tree is missing key 4, inserted before WriteTo")
+	}
+	if foundFive {
+		t.Fatalf("reopened tree still has key 5, deleted before WriteTo")
+	}
+}
+
+func TestOpenReadOnlyRejectsMutation(t *testing.T) {
+	tree, err := New([]int32{1, 3, 5})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer tree.Close()
+
+	var buf bytes.Buffer
+	if _, err := tree.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "tree.fast")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer ro.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Insert on a read-only tree did not panic")
+		}
+	}()
+	ro.Insert(2, 200)
+}
@@ -0,0 +1,45 @@
+package fast
+
+/*
+#include <fast.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Snapshot returns an independent copy of the tree as it is right now,
+// including any pending Insert/Delete mutations. Mutating t after Snapshot
+// does not affect the returned tree, and vice versa.
+//
+// fast.h exposes no reference-counted clone of its immutable layout, so
+// Snapshot pays for its independence with an O(n) rebuild of a fresh FAST
+// layout via fast_create rather than a cheap copy-on-write handle.
+func (t *Tree) Snapshot() *Tree {
+	entries := t.merged()
+	keys := make([]int32, len(entries))
+	values := make([]int64, len(entries))
+	for i, e := range entries {
+		keys[i] = e.key
+		values[i] = e.value
+	}
+
+	var ptr *C.fast_tree_t
+	if len(keys) > 0 {
+		ptr = C.fast_create((*C.int32_t)(unsafe.Pointer(&keys[0])), C.size_t(len(keys)))
+		if ptr == nil {
+			panic("fast: Snapshot: fast_create failed")
+		}
+	}
+
+	clone := &Tree{
+		ptr:            ptr,
+		baseValues:     values,
+		deltaThreshold: t.deltaThreshold,
+	}
+	runtime.SetFinalizer(clone, (*Tree).Close)
+	return clone
+}
@@ -0,0 +1,111 @@
+package fast
+
+import "golang.org/x/exp/constraints"
+
+// sortedIndex is the pure-Go backend for TreeOf: a single sorted key/value
+// slice pair searched with binary search. Get and Ascend/Descend are O(log
+// n) and O(n) respectively, same as a B+ tree leaf level, but Set/Delete
+// are O(n) due to the slice shift rather than O(log n) node-local inserts —
+// callers with very large or write-heavy TreeOf instances should account
+// for that.
+type sortedIndex[K constraints.Ordered, V any] struct {
+	keys   []K
+	values []V
+}
+
+func newSortedIndex[K constraints.Ordered, V any]() *sortedIndex[K, V] {
+	return &sortedIndex[K, V]{}
+}
+
+func (b *sortedIndex[K, V]) len() int { return len(b.keys) }
+
+// search returns the index of key, and whether it was found.
+func (b *sortedIndex[K, V]) search(key K) (int, bool) {
+	lo, hi := 0, len(b.keys)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case b.keys[mid] == key:
+			return mid, true
+		case b.keys[mid] < key:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return lo, false
+}
+
+func (b *sortedIndex[K, V]) get(key K) (V, bool) {
+	if i, ok := b.search(key); ok {
+		return b.values[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+func (b *sortedIndex[K, V]) set(key K, value V) (old V, existed bool) {
+	i, ok := b.search(key)
+	if ok {
+		old = b.values[i]
+		b.values[i] = value
+		return old, true
+	}
+	b.keys = append(b.keys, key)
+	copy(b.keys[i+1:], b.keys[i:])
+	b.keys[i] = key
+	b.values = append(b.values, value)
+	copy(b.values[i+1:], b.values[i:])
+	b.values[i] = value
+	var zero V
+	return zero, false
+}
+
+func (b *sortedIndex[K, V]) delete(key K) (old V, existed bool) {
+	i, ok := b.search(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	old = b.values[i]
+	b.keys = append(b.keys[:i], b.keys[i+1:]...)
+	b.values = append(b.values[:i], b.values[i+1:]...)
+	return old, true
+}
+
+func (b *sortedIndex[K, V]) ascend(fn func(key K, value V) bool) {
+	for i := range b.keys {
+		if !fn(b.keys[i], b.values[i]) {
+			return
+		}
+	}
+}
+
+func (b *sortedIndex[K, V]) ascendGE(pivot K, fn func(key K, value V) bool) {
+	i, _ := b.search(pivot)
+	for ; i < len(b.keys); i++ {
+		if !fn(b.keys[i], b.values[i]) {
+			return
+		}
+	}
+}
+
+func (b *sortedIndex[K, V]) descend(fn func(key K, value V) bool) {
+	for i := len(b.keys) - 1; i >= 0; i-- {
+		if !fn(b.keys[i], b.values[i]) {
+			return
+		}
+	}
+}
+
+func (b *sortedIndex[K, V]) descendLE(pivot K, fn func(key K, value V) bool) {
+	i, ok := b.search(pivot)
+	if !ok {
+		i--
+	}
+	for ; i >= 0; i-- {
+		if !fn(b.keys[i], b.values[i]) {
+			return
+		}
+	}
+}
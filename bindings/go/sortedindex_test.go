@@ -0,0 +1,125 @@
+package fast
+
+import "testing"
+
+func TestSortedIndexSetGetDelete(t *testing.T) {
+	idx := newSortedIndex[int32, string]()
+
+	if _, existed := idx.set(5, "five"); existed {
+		t.Fatalf("set(5) reported existed on first insert")
+	}
+	if _, existed := idx.set(3, "three"); existed {
+		t.Fatalf("set(3) reported existed on first insert")
+	}
+	if old, existed := idx.set(5, "FIVE"); !existed || old != "five" {
+		t.Fatalf("set(5) overwrite = (%q, %v), want (\"five\", true)", old, existed)
+	}
+
+	if v, ok := idx.get(5); !ok || v != "FIVE" {
+		t.Fatalf("get(5) = (%q, %v), want (\"FIVE\", true)", v, ok)
+	}
+	if _, ok := idx.get(99); ok {
+		t.Fatalf("get(99) found a key that was never set")
+	}
+
+	if old, ok := idx.delete(3); !ok || old != "three" {
+		t.Fatalf("delete(3) = (%q, %v), want (\"three\", true)", old, ok)
+	}
+	if _, ok := idx.delete(3); ok {
+		t.Fatalf("delete(3) a second time reported found")
+	}
+	if idx.len() != 1 {
+		t.Fatalf("len() = %d, want 1", idx.len())
+	}
+}
+
+func TestSortedIndexOrderedIteration(t *testing.T) {
+	idx := newSortedIndex[int32, int32]()
+	for _, k := range []int32{5, 1, 9, 3, 7} {
+		idx.set(k, k*10)
+	}
+
+	var ascending []int32
+	idx.ascend(func(k, _ int32) bool {
+		ascending = append(ascending, k)
+		return true
+	})
+	wantAsc := []int32{1, 3, 5, 7, 9}
+	if !equalInt32s(ascending, wantAsc) {
+		t.Fatalf("ascend order = %v, want %v", ascending, wantAsc)
+	}
+
+	var descending []int32
+	idx.descend(func(k, _ int32) bool {
+		descending = append(descending, k)
+		return true
+	})
+	wantDesc := []int32{9, 7, 5, 3, 1}
+	if !equalInt32s(descending, wantDesc) {
+		t.Fatalf("descend order = %v, want %v", descending, wantDesc)
+	}
+
+	var ge []int32
+	idx.ascendGE(5, func(k, _ int32) bool {
+		ge = append(ge, k)
+		return true
+	})
+	if !equalInt32s(ge, []int32{5, 7, 9}) {
+		t.Fatalf("ascendGE(5) = %v, want [5 7 9]", ge)
+	}
+
+	var le []int32
+	idx.descendLE(5, func(k, _ int32) bool {
+		le = append(le, k)
+		return true
+	})
+	if !equalInt32s(le, []int32{5, 3, 1}) {
+		t.Fatalf("descendLE(5) = %v, want [5 3 1]", le)
+	}
+}
+
+func equalInt32s(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTreeOfStringKeys(t *testing.T) {
+	tree := NewOf[string, int]()
+
+	tree.ReplaceOrInsert("banana", 2)
+	tree.ReplaceOrInsert("apple", 1)
+	tree.ReplaceOrInsert("cherry", 3)
+
+	if v, ok := tree.Get("apple"); !ok || v != 1 {
+		t.Fatalf("Get(apple) = (%d, %v), want (1, true)", v, ok)
+	}
+
+	var keys []string
+	tree.Ascend(func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	want := []string{"apple", "banana", "cherry"}
+	if len(keys) != len(want) {
+		t.Fatalf("Ascend visited %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Ascend visited %v, want %v", keys, want)
+		}
+	}
+
+	if old, existed := tree.Delete("banana"); !existed || old != 2 {
+		t.Fatalf("Delete(banana) = (%d, %v), want (2, true)", old, existed)
+	}
+	if tree.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", tree.Len())
+	}
+}
@@ -0,0 +1,68 @@
+package fast
+
+import "golang.org/x/exp/constraints"
+
+// TreeOf is a generic ordered tree mapping keys of type K to values of
+// type V. Its API shape mirrors github.com/google/btree and
+// github.com/tidwall/btree's generic trees so it can be used as a drop-in
+// replacement in code already written against either.
+//
+// K may be any of int32, int64, uint32, uint64, float32, float64, string,
+// or any other constraints.Ordered type. TreeOf is backed by a pure-Go
+// sorted index (see sortedindex.go) rather than the C FAST library, so the
+// API stays uniform across every key width; callers who specifically want
+// the C-backed search path and can live with its immutable-rebuild model
+// should use Tree (int32 keys) directly.
+//
+// An earlier revision dispatched int32-keyed TreeOf instances to the
+// C-backed Tree under the hood. That was removed deliberately, not an
+// oversight: with Insert/Set going through an immutable FAST layout, each
+// call forced a full tree rebuild for no read-side benefit over the
+// sorted-index path, so every K now takes the same pure-Go route.
+type TreeOf[K constraints.Ordered, V any] struct {
+	idx *sortedIndex[K, V]
+}
+
+// NewOf returns an empty TreeOf for the given key and value types.
+func NewOf[K constraints.Ordered, V any]() *TreeOf[K, V] {
+	return &TreeOf[K, V]{idx: newSortedIndex[K, V]()}
+}
+
+// Len returns the number of entries in the tree.
+func (t *TreeOf[K, V]) Len() int { return t.idx.len() }
+
+// Get returns the value associated with key, if present.
+func (t *TreeOf[K, V]) Get(key K) (V, bool) { return t.idx.get(key) }
+
+// Set associates value with key, returning the previous value if any.
+func (t *TreeOf[K, V]) Set(key K, value V) (old V, existed bool) {
+	return t.idx.set(key, value)
+}
+
+// ReplaceOrInsert is an alias for Set, matching google/btree's naming.
+func (t *TreeOf[K, V]) ReplaceOrInsert(key K, value V) (old V, existed bool) {
+	return t.Set(key, value)
+}
+
+// Delete removes key from the tree, returning its value if it was present.
+func (t *TreeOf[K, V]) Delete(key K) (old V, existed bool) {
+	return t.idx.delete(key)
+}
+
+// Ascend calls fn for every entry in ascending key order until fn returns false.
+func (t *TreeOf[K, V]) Ascend(fn func(key K, value V) bool) { t.idx.ascend(fn) }
+
+// AscendGreaterOrEqual calls fn for every entry with key >= pivot, in
+// ascending order, until fn returns false.
+func (t *TreeOf[K, V]) AscendGreaterOrEqual(pivot K, fn func(key K, value V) bool) {
+	t.idx.ascendGE(pivot, fn)
+}
+
+// Descend calls fn for every entry in descending key order until fn returns false.
+func (t *TreeOf[K, V]) Descend(fn func(key K, value V) bool) { t.idx.descend(fn) }
+
+// DescendLessOrEqual calls fn for every entry with key <= pivot, in
+// descending order, until fn returns false.
+func (t *TreeOf[K, V]) DescendLessOrEqual(pivot K, fn func(key K, value V) bool) {
+	t.idx.descendLE(pivot, fn)
+}